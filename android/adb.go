@@ -0,0 +1,90 @@
+package android
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// DeviceKind distinguishes a physical Android device from an emulator
+// instance in ParseADBDevices output.
+type DeviceKind string
+
+const (
+	RealDevice DeviceKind = "realDevice"
+	Emulator   DeviceKind = "emulator"
+)
+
+// Device is a single entry from `adb devices -l`.
+type Device struct {
+	Serial     string
+	Kind       DeviceKind
+	Qualifiers map[string]string
+	Product    string
+	Model      string
+	DeviceCode string
+}
+
+// ParseADBDevices parses the output of `adb devices -l`, e.g.:
+//
+//	List of devices attached
+//	deviceid01  device usb:3-3.4.3 product:bullhead model:Nexus_5X device:bullhead
+//	emulator-5554  device product:sdk_gphone64_x86_64 model:sdk_gphone64_x86_64 device:emu64x
+//
+// The "List of devices attached" header and blank lines are skipped. Serials
+// matching the "emulator-NNNN" convention are classified as Emulator;
+// everything else is RealDevice.
+func ParseADBDevices(r io.Reader) ([]Device, error) {
+	var devices []Device
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "List of devices attached") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		serial := fields[0]
+		kind := RealDevice
+		if strings.HasPrefix(serial, "emulator-") {
+			kind = Emulator
+		}
+
+		device := Device{
+			Serial:     serial,
+			Kind:       kind,
+			Qualifiers: map[string]string{},
+		}
+
+		// fields[1] is the connection state (device/offline/unauthorized);
+		// everything after it is "key:value" qualifiers.
+		for _, field := range fields[2:] {
+			key, value, ok := strings.Cut(field, ":")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "product":
+				device.Product = value
+			case "model":
+				device.Model = value
+			case "device":
+				device.DeviceCode = value
+			default:
+				device.Qualifiers[key] = value
+			}
+		}
+
+		devices = append(devices, device)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
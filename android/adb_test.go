@@ -0,0 +1,64 @@
+package android
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseADBDevicesSkipsHeader(t *testing.T) {
+	input := "List of devices attached\n" +
+		"deviceid01  device usb:3-3.4.3 product:bullhead model:Nexus_5X device:bullhead\n"
+
+	devices, err := ParseADBDevices(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseADBDevices 不应返回 error: %v", err)
+	}
+	if len(devices) != 1 {
+		t.Fatalf("应解析出 1 条设备记录，got=%d", len(devices))
+	}
+
+	d := devices[0]
+	if d.Serial != "deviceid01" {
+		t.Fatalf("Serial 解析异常: got=%q", d.Serial)
+	}
+	if d.Kind != RealDevice {
+		t.Fatalf("真机应识别为 RealDevice，got=%q", d.Kind)
+	}
+	if d.Product != "bullhead" || d.Model != "Nexus_5X" || d.DeviceCode != "bullhead" {
+		t.Fatalf("product/model/device 字段解析异常: %+v", d)
+	}
+	if d.Qualifiers["usb"] != "3-3.4.3" {
+		t.Fatalf("其余 key:value 应落入 Qualifiers，got=%+v", d.Qualifiers)
+	}
+}
+
+func TestParseADBDevicesEmulatorConvention(t *testing.T) {
+	input := "List of devices attached\n" +
+		"emulator-5554  device product:sdk_gphone64_x86_64 model:sdk_gphone64_x86_64 device:emu64x\n"
+
+	devices, err := ParseADBDevices(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseADBDevices 不应返回 error: %v", err)
+	}
+	if len(devices) != 1 {
+		t.Fatalf("应解析出 1 条设备记录，got=%d", len(devices))
+	}
+	if devices[0].Kind != Emulator {
+		t.Fatalf("emulator-NNNN 应识别为 Emulator，got=%q", devices[0].Kind)
+	}
+}
+
+func TestParseADBDevicesMultipleLines(t *testing.T) {
+	input := "List of devices attached\n" +
+		"deviceid01  device usb:3-3.4.3 product:bullhead model:Nexus_5X device:bullhead\n" +
+		"\n" +
+		"emulator-5554  device product:sdk_gphone64_x86_64 model:sdk_gphone64_x86_64 device:emu64x\n"
+
+	devices, err := ParseADBDevices(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseADBDevices 不应返回 error: %v", err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("应跳过空行并解析出 2 条设备记录，got=%d", len(devices))
+	}
+}
@@ -0,0 +1,37 @@
+// Package android parses Android device identifiers, starting with the
+// `adb devices -l` output format and a codename-to-marketing-name map in the
+// same spirit as the top-level devicemodel package's Apple data.
+package android
+
+import (
+	"embed"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+//go:embed data/android-device-identifiers.json
+var embeddedData embed.FS
+
+var (
+	loadOnce sync.Once
+	models   map[string]string
+)
+
+// LookupAndroidModel returns the marketing name for an Android codename
+// (e.g. "bullhead" -> "Nexus 5X"), or "" if deviceCode is unknown.
+func LookupAndroidModel(deviceCode string) string {
+	loadModels()
+	return models[strings.TrimSpace(deviceCode)]
+}
+
+func loadModels() {
+	loadOnce.Do(func() {
+		models = map[string]string{}
+		b, err := embeddedData.ReadFile("data/android-device-identifiers.json")
+		if err != nil {
+			return
+		}
+		_ = json.Unmarshal(b, &models)
+	})
+}
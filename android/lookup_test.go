@@ -0,0 +1,22 @@
+package android
+
+import "testing"
+
+func TestLookupAndroidModelKnownCodename(t *testing.T) {
+	name := LookupAndroidModel("bullhead")
+	if name != "Nexus 5X" {
+		t.Fatalf("LookupAndroidModel(bullhead) 应返回 Nexus 5X，got=%q", name)
+	}
+}
+
+func TestLookupAndroidModelUnknownCodename(t *testing.T) {
+	if name := LookupAndroidModel("unknown-codename"); name != "" {
+		t.Fatalf("未知 codename 应返回空字符串，got=%q", name)
+	}
+}
+
+func TestLookupAndroidModelTrimsWhitespace(t *testing.T) {
+	if name := LookupAndroidModel("  taimen  "); name != "Pixel 2 XL" {
+		t.Fatalf("LookupAndroidModel 应去除首尾空白，got=%q", name)
+	}
+}
@@ -0,0 +1,150 @@
+package devicemodel
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Confidence describes how a LookupApproximate result was derived.
+type Confidence string
+
+const (
+	// Unknown means code couldn't be resolved at all, exactly or otherwise.
+	Unknown Confidence = "unknown"
+	// FamilyOnly means code's (major, minor) pair is older than every known
+	// device in its family; the oldest known device was returned instead.
+	FamilyOnly Confidence = "family_only"
+	// Approximate means code wasn't found, but the newest known device at
+	// or below its (major, minor) pair was returned as a stand-in.
+	Approximate Confidence = "approximate"
+	// Exact means code matched a known device precisely.
+	Exact Confidence = "exact"
+)
+
+// identifierPattern splits an Apple-style hardware identifier into its
+// family prefix and numeric (major, minor) generation, e.g. "iPhone18,1"
+// -> ("iPhone", 18, 1).
+var identifierPattern = regexp.MustCompile(`^([A-Za-z]+)(\d+),(\d+)$`)
+
+type parsedIdentifier struct {
+	familyPrefix string
+	major, minor int
+}
+
+func parseIdentifier(code string) (parsedIdentifier, bool) {
+	m := identifierPattern.FindStringSubmatch(code)
+	if m == nil {
+		return parsedIdentifier{}, false
+	}
+	major, err := strconv.Atoi(m[2])
+	if err != nil {
+		return parsedIdentifier{}, false
+	}
+	minor, err := strconv.Atoi(m[3])
+	if err != nil {
+		return parsedIdentifier{}, false
+	}
+	return parsedIdentifier{familyPrefix: m[1], major: major, minor: minor}, true
+}
+
+// LookupApproximate resolves code against the default registry, falling
+// back to the nearest known device in the same family when code itself is
+// unrecognised (e.g. a device released after this module's data was last
+// updated).
+func LookupApproximate(code string) (DeviceInfo, Confidence) {
+	return defaultRegistry().LookupApproximate(code)
+}
+
+// LookupApproximate resolves code exactly where possible. On a miss, it
+// parses code as "<familyPrefix><major>,<minor>" and walks the known
+// identifiers in that family backward from (major, minor), returning the
+// newest one that's no newer than code with Confidence Approximate. If even
+// the oldest known identifier in the family is newer than code, that oldest
+// entry is returned instead with Confidence FamilyOnly.
+func (r *Registry) LookupApproximate(code string) (DeviceInfo, Confidence) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return DeviceInfo{}, Unknown
+	}
+
+	if info, ok := r.LookupInfo(code); ok {
+		return info, Exact
+	}
+
+	parsed, ok := parseIdentifier(code)
+	if !ok {
+		return DeviceInfo{}, Unknown
+	}
+
+	label, canonical, ok := platformForPrefix(parsed.familyPrefix)
+	if !ok {
+		return DeviceInfo{}, Unknown
+	}
+
+	st := r.state.Load()
+	family := sortedFamily(st, canonical, parsed.familyPrefix)
+	if len(family) == 0 {
+		return DeviceInfo{}, Unknown
+	}
+
+	for _, entry := range family {
+		if entry.major < parsed.major || (entry.major == parsed.major && entry.minor <= parsed.minor) {
+			info, _ := lookupRecord(st, label, canonical, entry.code)
+			info.Name = approximateName(info.Name)
+			return info, Approximate
+		}
+	}
+
+	oldest := family[len(family)-1]
+	info, _ := lookupRecord(st, label, canonical, oldest.code)
+	return info, FamilyOnly
+}
+
+// approximateName turns a known device's marketing name into the synthesised
+// label a LookupApproximate caller sees for a newer, unrecognised sibling.
+func approximateName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return name + " or newer"
+}
+
+type familyEntry struct {
+	code  string
+	major int
+	minor int
+}
+
+// sortedFamily returns every identifier under st.records[canonical] whose
+// family prefix is exactly prefix, sorted by (major, minor) descending. The
+// result is memoized on st so repeated LookupApproximate calls against the
+// same registryState don't re-scan and re-sort every time.
+func sortedFamily(st *registryState, canonical, prefix string) []familyEntry {
+	cacheKey := canonical + "|" + prefix
+	if cached, ok := st.familyCache.Load(cacheKey); ok {
+		return cached.([]familyEntry)
+	}
+
+	var entries []familyEntry
+	for code, rec := range st.records[canonical] {
+		if rec.Name == "" {
+			continue
+		}
+		parsed, ok := parseIdentifier(code)
+		if !ok || parsed.familyPrefix != prefix {
+			continue
+		}
+		entries = append(entries, familyEntry{code: code, major: parsed.major, minor: parsed.minor})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].major != entries[j].major {
+			return entries[i].major > entries[j].major
+		}
+		return entries[i].minor > entries[j].minor
+	})
+
+	st.familyCache.Store(cacheKey, entries)
+	return entries
+}
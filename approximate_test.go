@@ -0,0 +1,60 @@
+package devicemodel
+
+import "testing"
+
+func newApproximateRegistry() *Registry {
+	return NewRegistry(WithoutEmbedded(), WithOverrides(map[string]map[string]DeviceRecord{
+		PlatformIOS: {
+			"iPhone16,1": {Name: "iPhone 15 Pro"},
+			"iPhone17,1": {Name: "iPhone 16 Pro"},
+			"iPhone17,2": {Name: "iPhone 16 Pro Max"},
+		},
+	}))
+}
+
+func TestLookupApproximateExactHit(t *testing.T) {
+	reg := newApproximateRegistry()
+	info, confidence := reg.LookupApproximate("iPhone17,1")
+	if confidence != Exact {
+		t.Fatalf("已知标识符应返回 Exact，got=%v", confidence)
+	}
+	if info.Name != "iPhone 16 Pro" {
+		t.Fatalf("已知标识符 name 异常: got=%q", info.Name)
+	}
+}
+
+func TestLookupApproximateNewerSiblingFallsBackToNewestKnown(t *testing.T) {
+	reg := newApproximateRegistry()
+	info, confidence := reg.LookupApproximate("iPhone19,1")
+	if confidence != Approximate {
+		t.Fatalf("更新的未知型号应返回 Approximate，got=%v", confidence)
+	}
+	if info.Name != "iPhone 16 Pro Max or newer" {
+		t.Fatalf("应回退到同家族最新已知型号并加上后缀，got=%q", info.Name)
+	}
+}
+
+func TestLookupApproximateOlderThanAllKnownFallsBackToOldest(t *testing.T) {
+	reg := newApproximateRegistry()
+	info, confidence := reg.LookupApproximate("iPhone10,1")
+	if confidence != FamilyOnly {
+		t.Fatalf("早于所有已知型号应返回 FamilyOnly，got=%v", confidence)
+	}
+	if info.Name != "iPhone 15 Pro" {
+		t.Fatalf("应回退到同家族最旧已知型号，got=%q", info.Name)
+	}
+}
+
+func TestLookupApproximateUnknownFamily(t *testing.T) {
+	reg := newApproximateRegistry()
+	if _, confidence := reg.LookupApproximate("PixelWatch3,1"); confidence != Unknown {
+		t.Fatalf("完全未知的家族应返回 Unknown，got=%v", confidence)
+	}
+}
+
+func TestLookupApproximateMalformedIdentifier(t *testing.T) {
+	reg := newApproximateRegistry()
+	if _, confidence := reg.LookupApproximate("not-an-identifier"); confidence != Unknown {
+		t.Fatalf("无法解析的标识符应返回 Unknown，got=%v", confidence)
+	}
+}
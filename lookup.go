@@ -1,10 +1,8 @@
 package devicemodel
 
 import (
-	"embed"
 	"encoding/json"
 	"strings"
-	"sync"
 )
 
 const (
@@ -14,183 +12,237 @@ const (
 	PlatformTVOS     = "tvos"
 	PlatformWatchOS  = "watchos"
 	PlatformVisionOS = "visionos"
-)
 
-//go:embed data/ios-device-identifiers.json data/mac-device-identifiers.json data/tvos-device-identifiers.json data/watchos-device-identifiers.json data/visionos-device-identifiers.json data/UPSTREAM.json
-var embeddedData embed.FS
+	PlatformBridgeOS    = "bridgeos"
+	PlatformMacCatalyst = "maccatalyst"
+	PlatformDriverKit   = "driverkit"
+
+	PlatformIOSSimulator      = "ios-simulator"
+	PlatformTVOSSimulator     = "tvos-simulator"
+	PlatformWatchOSSimulator  = "watchos-simulator"
+	PlatformVisionOSSimulator = "visionos-simulator"
 
-type upstreamMeta struct {
-	UpstreamRepo string `json:"upstream_repo"`
-	UpstreamRef  string `json:"upstream_ref"`
-	UpstreamSHA  string `json:"upstream_sha"`
-	SyncedAtUTC  string `json:"synced_at_utc"`
+	// PlatformAndroid is returned by LookupInfo/LookupDetailed when an
+	// identifier doesn't match any Apple prefix but is a known entry in the
+	// android sub-package's codename map.
+	PlatformAndroid = "android"
+)
+
+// DeviceRecord is the on-disk shape of a single entry in a data source. Most
+// entries are rich objects, but older data files may still carry a bare
+// marketing-name string, or a `[]string` of name variants (the first
+// non-empty one wins) - UnmarshalJSON accepts all three so both shapes load
+// without a migration step.
+type DeviceRecord struct {
+	Name             string `json:"name"`
+	Family           string `json:"family,omitempty"`
+	FormFactor       string `json:"form_factor,omitempty"`
+	Generation       string `json:"generation,omitempty"`
+	ReleaseYear      int    `json:"year,omitempty"`
+	Chip             string `json:"chip,omitempty"`
+	Discontinued     bool   `json:"discontinued,omitempty"`
+	SupportedOSRange string `json:"supported_os_range,omitempty"`
 }
 
-var (
-	loadOnce sync.Once
+func (d *DeviceRecord) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		d.Name = strings.TrimSpace(name)
+		return nil
+	}
 
-	iosMap      map[string]string
-	macosMap    map[string]string
-	tvosMap     map[string]string
-	watchosMap  map[string]string
-	visionosMap map[string]string
-	meta        upstreamMeta
-)
+	var variants []string
+	if err := json.Unmarshal(b, &variants); err == nil {
+		for _, variant := range variants {
+			variant = strings.TrimSpace(variant)
+			if variant != "" {
+				d.Name = variant
+				break
+			}
+		}
+		return nil
+	}
+
+	type rawRecord DeviceRecord
+	var raw rawRecord
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*d = DeviceRecord(raw)
+	d.Name = strings.TrimSpace(d.Name)
+	return nil
+}
+
+// DeviceInfo is the structured result of a lookup, combining the record data
+// with the identifier and platform it was resolved against.
+type DeviceInfo struct {
+	Identifier       string
+	Name             string
+	Platform         string
+	Family           string
+	FormFactor       string
+	Generation       string
+	ReleaseYear      int
+	Chip             string
+	Discontinued     bool
+	SupportedOSRange string
+}
 
 type LookupDetail struct {
 	Platform string `json:"platform,omitempty"`
 	Name     string `json:"name,omitempty"`
 }
 
+// Lookup resolves code against the default registry, auto-detecting its
+// platform from the identifier's prefix.
 func Lookup(code string) string {
-	return LookupDetailed(code).Name
+	return defaultRegistry().Lookup(code)
 }
 
+// LookupDetailed resolves code against the default registry.
 func LookupDetailed(code string) LookupDetail {
-	loadAll()
+	return defaultRegistry().LookupDetailed(code)
+}
 
-	code = strings.TrimSpace(code)
-	if code == "" {
-		return LookupDetail{}
-	}
+// LookupInfo resolves code against the default registry.
+func LookupInfo(code string) (DeviceInfo, bool) {
+	return defaultRegistry().LookupInfo(code)
+}
 
-	if detail := lookupByPrefixDetailed(code); detail.Name != "" {
-		return detail
-	}
+// LookupWithPlatform resolves code against the default registry for platform.
+func LookupWithPlatform(platform, code string) string {
+	return defaultRegistry().LookupWithPlatform(platform, code)
+}
 
-	if name := iosMap[code]; name != "" {
-		return LookupDetail{Platform: PlatformIOS, Name: name}
-	}
-	if name := macosMap[code]; name != "" {
-		return LookupDetail{Platform: PlatformMACOS, Name: name}
-	}
-	if name := tvosMap[code]; name != "" {
-		return LookupDetail{Platform: PlatformTVOS, Name: name}
-	}
-	if name := watchosMap[code]; name != "" {
-		return LookupDetail{Platform: PlatformWatchOS, Name: name}
-	}
-	if name := visionosMap[code]; name != "" {
-		return LookupDetail{Platform: PlatformVisionOS, Name: name}
-	}
+// LookupDetailWithPlatform resolves code against the default registry for
+// platform, preserving platform labels (e.g. a simulator or Mac Catalyst
+// variant) that share their identifier space with a host OS map.
+func LookupDetailWithPlatform(platform, code string) LookupDetail {
+	return defaultRegistry().LookupDetailWithPlatform(platform, code)
+}
 
-	return LookupDetail{}
+// LookupInfoWithPlatform resolves code against the default registry for
+// platform.
+func LookupInfoWithPlatform(platform, code string) (DeviceInfo, bool) {
+	return defaultRegistry().LookupInfoWithPlatform(platform, code)
 }
 
-func LookupWithPlatform(platform, code string) string {
-	loadAll()
+// ReverseLookup returns every identifier in the default registry whose
+// marketing name matches name, preferring case-insensitive exact matches and
+// falling back to fuzzy matches (Levenshtein distance <= 2) when none exist.
+func ReverseLookup(name string) []string {
+	return defaultRegistry().ReverseLookup(name)
+}
+
+// DataVersion reports the upstream provenance of the default registry's
+// embedded data.
+func DataVersion() (upstreamRepo, upstreamRef, syncedAtUTC string) {
+	return defaultRegistry().DataVersion()
+}
+
+// Reload refreshes the default registry's data from its sources. The default
+// registry only has the built-in embedded source, so this re-reads the same
+// compiled-in data; registries built with NewRegistry and extra sources
+// (fs.FS, HTTP, overrides) are refreshed through their own Reload method.
+func Reload() error {
+	return defaultRegistry().Reload()
+}
 
-	code = strings.TrimSpace(code)
-	if code == "" {
+// NormalizedPlatform maps informal platform spellings ("iOS-simulator",
+// "catalyst", "mac-catalyst", "bridgeos", ...) onto the Platform* constants.
+// Unrecognised but non-empty input is returned lower-cased and trimmed so
+// callers can still compare it against a Platform* constant directly.
+func NormalizedPlatform(platform string) string {
+	p := strings.TrimSpace(strings.ToLower(platform))
+	if p == "" {
 		return ""
 	}
 
-	platform = normalizePlatform(platform)
-	if platform == "" {
-		return Lookup(code)
+	switch p {
+	case "ios-simulator", "ios simulator", "iossimulator":
+		return PlatformIOSSimulator
+	case "tvos-simulator", "tvos simulator", "tvossimulator":
+		return PlatformTVOSSimulator
+	case "watchos-simulator", "watchos simulator", "watchossimulator":
+		return PlatformWatchOSSimulator
+	case "visionos-simulator", "visionos simulator", "visionossimulator":
+		return PlatformVisionOSSimulator
+	case "catalyst", "mac-catalyst", "mac catalyst", "maccatalyst":
+		return PlatformMacCatalyst
+	case "bridgeos", "bridge-os", "bridge os":
+		return PlatformBridgeOS
+	case "driverkit", "driver-kit", "driver kit":
+		return PlatformDriverKit
+	default:
+		return p
 	}
+}
 
+// canonicalDataPlatform maps a normalized platform (which may be a
+// simulator or Catalyst variant) onto the data-source platform key whose
+// identifier space it shares, e.g. PlatformIOSSimulator -> PlatformIOS.
+func canonicalDataPlatform(platform string) string {
+	switch platform {
+	case PlatformIOS, PlatformIPADOS, PlatformMacCatalyst, PlatformIOSSimulator:
+		return PlatformIOS
+	case PlatformMACOS:
+		return PlatformMACOS
+	case PlatformTVOS, PlatformTVOSSimulator:
+		return PlatformTVOS
+	case PlatformWatchOS, PlatformWatchOSSimulator:
+		return PlatformWatchOS
+	case PlatformVisionOS, PlatformVisionOSSimulator:
+		return PlatformVisionOS
+	case PlatformBridgeOS:
+		return PlatformBridgeOS
+	case PlatformDriverKit:
+		return PlatformDriverKit
+	default:
+		return ""
+	}
+}
+
+// dataFileName returns the conventional file name a DataSource serves a
+// platform's records from, e.g. PlatformMACOS -> "mac-device-identifiers.json".
+func dataFileName(platform string) (string, bool) {
 	switch platform {
 	case PlatformIOS:
-		return iosMap[code]
-	case PlatformIPADOS:
-		return iosMap[code]
+		return "ios-device-identifiers.json", true
 	case PlatformMACOS:
-		return macosMap[code]
+		return "mac-device-identifiers.json", true
 	case PlatformTVOS:
-		return tvosMap[code]
+		return "tvos-device-identifiers.json", true
 	case PlatformWatchOS:
-		return watchosMap[code]
+		return "watchos-device-identifiers.json", true
 	case PlatformVisionOS:
-		return visionosMap[code]
+		return "visionos-device-identifiers.json", true
+	case PlatformBridgeOS:
+		return "bridgeos-device-identifiers.json", true
+	case PlatformDriverKit:
+		return "driverkit-device-identifiers.json", true
 	default:
-		return ""
+		return "", false
 	}
 }
 
-func DataVersion() (upstreamRepo, upstreamRef, syncedAtUTC string) {
-	loadAll()
-	return meta.UpstreamRepo, meta.UpstreamRef + " sha:" + meta.UpstreamSHA, meta.SyncedAtUTC
-}
-
-func normalizePlatform(platform string) string {
-	platform = strings.TrimSpace(strings.ToLower(platform))
-	return platform
-}
-
-func lookupByPrefixDetailed(code string) LookupDetail {
+// familyFromCode derives the coarse device family (iPhone/iPad/Mac/Watch/
+// TV/Vision) from an identifier's prefix, used when a DeviceRecord doesn't
+// set Family explicitly.
+func familyFromCode(code string) string {
 	switch {
 	case strings.HasPrefix(code, "iPhone"), strings.HasPrefix(code, "iPod"):
-		return LookupDetail{Platform: PlatformIOS, Name: iosMap[code]}
+		return "iPhone"
 	case strings.HasPrefix(code, "iPad"):
-		return LookupDetail{Platform: PlatformIPADOS, Name: iosMap[code]}
+		return "iPad"
 	case strings.HasPrefix(code, "Watch"):
-		return LookupDetail{Platform: PlatformWatchOS, Name: watchosMap[code]}
+		return "Watch"
 	case strings.HasPrefix(code, "AppleTV"):
-		return LookupDetail{Platform: PlatformTVOS, Name: tvosMap[code]}
+		return "TV"
 	case strings.HasPrefix(code, "RealityDevice"):
-		return LookupDetail{Platform: PlatformVisionOS, Name: visionosMap[code]}
-	case strings.HasPrefix(code, "iMac"), strings.HasPrefix(code, "Mac"):
-		return LookupDetail{Platform: PlatformMACOS, Name: macosMap[code]}
+		return "Vision"
+	case strings.HasPrefix(code, "iMac"), strings.HasPrefix(code, "Mac"), strings.HasPrefix(code, "iBridge"):
+		return "Mac"
 	default:
-		return LookupDetail{}
-	}
-}
-
-func loadAll() {
-	loadOnce.Do(func() {
-		iosMap = readDeviceMap("data/ios-device-identifiers.json")
-		macosMap = readDeviceMap("data/mac-device-identifiers.json")
-		tvosMap = readDeviceMap("data/tvos-device-identifiers.json")
-		watchosMap = readDeviceMap("data/watchos-device-identifiers.json")
-		visionosMap = readDeviceMap("data/visionos-device-identifiers.json")
-		meta = readUpstreamMeta("data/UPSTREAM.json")
-	})
-}
-
-func readDeviceMap(path string) map[string]string {
-	out := map[string]string{}
-	b, err := embeddedData.ReadFile(path)
-	if err != nil {
-		return out
-	}
-
-	var raw map[string]any
-	if err := json.Unmarshal(b, &raw); err != nil {
-		return out
-	}
-	for key, value := range raw {
-		if modelName := firstModelName(value); modelName != "" {
-			out[key] = modelName
-		}
-	}
-
-	return out
-}
-
-func firstModelName(value any) string {
-	switch typed := value.(type) {
-	case string:
-		return strings.TrimSpace(typed)
-	case []any:
-		for _, item := range typed {
-			if s, ok := item.(string); ok {
-				s = strings.TrimSpace(s)
-				if s != "" {
-					return s
-				}
-			}
-		}
-	}
-	return ""
-}
-
-func readUpstreamMeta(path string) upstreamMeta {
-	var out upstreamMeta
-	b, err := embeddedData.ReadFile(path)
-	if err != nil {
-		return out
+		return ""
 	}
-	_ = json.Unmarshal(b, &out)
-	return out
 }
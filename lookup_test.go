@@ -1,6 +1,7 @@
 package devicemodel
 
 import (
+	"encoding/json"
 	"strings"
 	"sync"
 	"testing"
@@ -113,6 +114,112 @@ func TestLookupWithEmptyPlatformAutoDetect(t *testing.T) {
 	}
 }
 
+func TestNormalizedPlatformAliases(t *testing.T) {
+	cases := map[string]string{
+		"iOS-simulator": PlatformIOSSimulator,
+		"catalyst":      PlatformMacCatalyst,
+		"mac-catalyst":  PlatformMacCatalyst,
+		"bridgeos":      PlatformBridgeOS,
+		" DriverKit ":   PlatformDriverKit,
+		"watchos":       PlatformWatchOS,
+	}
+	for input, want := range cases {
+		if got := NormalizedPlatform(input); got != want {
+			t.Fatalf("NormalizedPlatform(%q) 应返回 %q，got=%q", input, want, got)
+		}
+	}
+}
+
+func TestLookupWithPlatformSimulatorFallsBackToHostMap(t *testing.T) {
+	detail := LookupDetailWithPlatform(PlatformIOSSimulator, "iPhone18,1")
+	if detail.Name == "" {
+		t.Fatalf("LookupDetailWithPlatform(ios-simulator, iPhone18,1) 应返回非空")
+	}
+	if detail.Platform != PlatformIOSSimulator {
+		t.Fatalf("LookupDetailWithPlatform 应保留 simulator 平台标签，got=%q", detail.Platform)
+	}
+}
+
+func TestLookupWithPlatformMacCatalystFallsBackToIPadMap(t *testing.T) {
+	name := LookupWithPlatform(PlatformMacCatalyst, "iPad16,3")
+	if name == "" {
+		t.Fatalf("LookupWithPlatform(maccatalyst, iPad16,3) 应返回非空")
+	}
+}
+
+func TestLookupWithPlatformBridgeOSUnknown(t *testing.T) {
+	name := LookupWithPlatform(PlatformBridgeOS, "iBridge2,1")
+	if name != "" {
+		t.Fatalf("空数据文件应返回空字符串，got=%q", name)
+	}
+}
+
+func TestLookupInfoPopulatesMetadata(t *testing.T) {
+	info, ok := LookupInfo("iPhone18,1")
+	if !ok {
+		t.Fatalf("LookupInfo(iPhone18,1) 应命中")
+	}
+	if info.Name == "" {
+		t.Fatalf("LookupInfo(iPhone18,1) name 应返回非空")
+	}
+	if info.Identifier != "iPhone18,1" {
+		t.Fatalf("LookupInfo 应回填 Identifier，got=%q", info.Identifier)
+	}
+	if info.Platform != PlatformIOS {
+		t.Fatalf("LookupInfo(iPhone18,1) platform 异常: got=%q", info.Platform)
+	}
+	if info.Family != "iPhone" {
+		t.Fatalf("LookupInfo(iPhone18,1) family 应为 iPhone，got=%q", info.Family)
+	}
+}
+
+func TestLookupInfoUnknownReturnsFalse(t *testing.T) {
+	if _, ok := LookupInfo("Unknown,0"); ok {
+		t.Fatalf("LookupInfo(Unknown,0) 应返回 ok=false")
+	}
+}
+
+func TestDeviceRecordUnmarshalsLegacyStringShape(t *testing.T) {
+	var rec DeviceRecord
+	if err := json.Unmarshal([]byte(`"iPhone 16 Pro"`), &rec); err != nil {
+		t.Fatalf("解析旧版 string 格式失败: %v", err)
+	}
+	if rec.Name != "iPhone 16 Pro" {
+		t.Fatalf("旧版 string 格式应回填 Name，got=%q", rec.Name)
+	}
+}
+
+func TestDeviceRecordUnmarshalsLegacyArrayShape(t *testing.T) {
+	var rec DeviceRecord
+	if err := json.Unmarshal([]byte(`["", "iPhone 16 Pro", "iPhone16,2"]`), &rec); err != nil {
+		t.Fatalf("解析旧版 []string 格式失败: %v", err)
+	}
+	if rec.Name != "iPhone 16 Pro" {
+		t.Fatalf("旧版 []string 格式应取第一个非空项，got=%q", rec.Name)
+	}
+}
+
+func TestDeviceRecordUnmarshalsRichObjectShape(t *testing.T) {
+	var rec DeviceRecord
+	body := `{"name": "iPhone 16 Pro", "chip": "A18 Pro", "year": 2024, "discontinued": false}`
+	if err := json.Unmarshal([]byte(body), &rec); err != nil {
+		t.Fatalf("解析新版 object 格式失败: %v", err)
+	}
+	if rec.Name != "iPhone 16 Pro" || rec.Chip != "A18 Pro" || rec.ReleaseYear != 2024 {
+		t.Fatalf("新版 object 格式字段丢失: %+v", rec)
+	}
+}
+
+func TestLookupDetailedFallsBackToAndroidModel(t *testing.T) {
+	detail := LookupDetailed("bullhead")
+	if detail.Name != "Nexus 5X" {
+		t.Fatalf("非 Apple 前缀应回退到 Android 型号表，got=%q", detail.Name)
+	}
+	if detail.Platform != PlatformAndroid {
+		t.Fatalf("Android 回退的 platform 应为 %q，got=%q", PlatformAndroid, detail.Platform)
+	}
+}
+
 func TestDataVersion(t *testing.T) {
 	repo, ref, syncedAt := DataVersion()
 	if strings.TrimSpace(repo) == "" {
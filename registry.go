@@ -0,0 +1,459 @@
+package devicemodel
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cypacky-io/device-parser/android"
+)
+
+//go:embed data/ios-device-identifiers.json data/mac-device-identifiers.json data/tvos-device-identifiers.json data/watchos-device-identifiers.json data/visionos-device-identifiers.json data/bridgeos-device-identifiers.json data/driverkit-device-identifiers.json data/UPSTREAM.json
+var embeddedData embed.FS
+
+// knownPlatforms are the data-source platform keys a Registry loads on every
+// Reload. Simulator, Catalyst, and iPadOS variants aren't listed here - they
+// resolve against one of these via canonicalDataPlatform.
+var knownPlatforms = []string{
+	PlatformIOS,
+	PlatformMACOS,
+	PlatformTVOS,
+	PlatformWatchOS,
+	PlatformVisionOS,
+	PlatformBridgeOS,
+	PlatformDriverKit,
+}
+
+type upstreamMeta struct {
+	UpstreamRepo string `json:"upstream_repo"`
+	UpstreamRef  string `json:"upstream_ref"`
+	UpstreamSHA  string `json:"upstream_sha"`
+	SyncedAtUTC  string `json:"synced_at_utc"`
+}
+
+func readUpstreamMeta(path string) upstreamMeta {
+	var out upstreamMeta
+	b, err := embeddedData.ReadFile(path)
+	if err != nil {
+		return out
+	}
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+// DataSource loads the known records for a single platform (one of the
+// Platform* constants that canonicalDataPlatform resolves to). Returning a
+// nil map with a nil error means the source simply has nothing for that
+// platform.
+type DataSource interface {
+	Load(platform string) (map[string]DeviceRecord, error)
+}
+
+// embeddedSource serves the data files compiled into the binary via
+// go:embed. It's always the first source in a Registry, so every other
+// source can selectively override a subset of its entries.
+type embeddedSource struct{}
+
+func (embeddedSource) Load(platform string) (map[string]DeviceRecord, error) {
+	name, ok := dataFileName(platform)
+	if !ok {
+		return nil, nil
+	}
+	b, err := embeddedData.ReadFile("data/" + name)
+	if err != nil {
+		return nil, nil
+	}
+	out := map[string]DeviceRecord{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("devicemodel: decode embedded %s: %w", name, err)
+	}
+	return out, nil
+}
+
+// fsSource serves records from a caller-supplied fs.FS, using the same file
+// naming convention as the embedded source (minus the "data/" prefix). Use
+// it to patch in identifiers Apple ships between module releases without
+// waiting on a new module version.
+type fsSource struct {
+	fsys fs.FS
+}
+
+// WithFS adds an fs.FS-backed DataSource to a Registry.
+func WithFS(fsys fs.FS) Option {
+	return func(r *Registry) { r.sources = append(r.sources, fsSource{fsys: fsys}) }
+}
+
+func (s fsSource) Load(platform string) (map[string]DeviceRecord, error) {
+	name, ok := dataFileName(platform)
+	if !ok {
+		return nil, nil
+	}
+	b, err := fs.ReadFile(s.fsys, name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("devicemodel: read %s: %w", name, err)
+	}
+	out := map[string]DeviceRecord{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("devicemodel: decode %s: %w", name, err)
+	}
+	return out, nil
+}
+
+// httpCacheEntry remembers the validators and payload from the last
+// successful fetch of a platform's data, so a 304 response can be served
+// without re-parsing anything.
+type httpCacheEntry struct {
+	etag         string
+	lastModified string
+	records      map[string]DeviceRecord
+}
+
+// httpSource fetches fresh JSON from <baseURL>/<platform-file> on every
+// Load, reusing the cached payload on a conditional-GET 304.
+type httpSource struct {
+	baseURL string
+	client  *http.Client
+
+	mu    sync.Mutex
+	cache map[string]httpCacheEntry
+}
+
+// WithHTTPSource adds a DataSource that pulls fresh JSON from baseURL,
+// sending If-None-Match/If-Modified-Since once it has fetched a platform at
+// least once. A nil client uses http.DefaultClient.
+func WithHTTPSource(baseURL string, client *http.Client) Option {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	src := &httpSource{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+		cache:   map[string]httpCacheEntry{},
+	}
+	return func(r *Registry) { r.sources = append(r.sources, src) }
+}
+
+func (s *httpSource) Load(platform string) (map[string]DeviceRecord, error) {
+	name, ok := dataFileName(platform)
+	if !ok {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	cached, hasCache := s.cache[platform]
+	s.mu.Unlock()
+
+	req, err := http.NewRequest(http.MethodGet, s.baseURL+"/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hasCache {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if hasCache {
+			return cached.records, nil
+		}
+		return nil, fmt.Errorf("devicemodel: fetch %s: %w", req.URL, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return cached.records, nil
+	case resp.StatusCode != http.StatusOK:
+		if hasCache {
+			return cached.records, nil
+		}
+		return nil, fmt.Errorf("devicemodel: fetch %s: unexpected status %s", req.URL, resp.Status)
+	}
+
+	out := map[string]DeviceRecord{}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("devicemodel: decode %s: %w", req.URL, err)
+	}
+
+	s.mu.Lock()
+	s.cache[platform] = httpCacheEntry{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		records:      out,
+	}
+	s.mu.Unlock()
+
+	return out, nil
+}
+
+// overrideSource serves an in-memory map supplied at Registry construction
+// time, keyed by the same platform constants as dataFileName.
+type overrideSource struct {
+	records map[string]map[string]DeviceRecord
+}
+
+// WithOverrides adds an in-memory DataSource, keyed by Platform* constant,
+// useful for tests or for patching a handful of identifiers without standing
+// up an fs.FS or HTTP endpoint.
+func WithOverrides(records map[string]map[string]DeviceRecord) Option {
+	return func(r *Registry) { r.sources = append(r.sources, overrideSource{records: records}) }
+}
+
+func (s overrideSource) Load(platform string) (map[string]DeviceRecord, error) {
+	return s.records[platform], nil
+}
+
+// registryState is the immutable snapshot a Registry swaps in on Reload.
+// familyCache memoizes the sorted family slices LookupApproximate builds on
+// first use; since a Reload always installs a fresh registryState, the
+// cache is implicitly invalidated along with the data it was built from.
+type registryState struct {
+	records     map[string]map[string]DeviceRecord
+	meta        upstreamMeta
+	familyCache sync.Map
+}
+
+// Registry composes one or more DataSources and answers lookups from the
+// merged, most-recently-reloaded snapshot. The zero-value Registry is not
+// usable; build one with NewRegistry.
+type Registry struct {
+	sources      []DataSource
+	skipEmbedded bool
+	state        atomic.Pointer[registryState]
+}
+
+// Option configures a Registry constructed by NewRegistry.
+type Option func(*Registry)
+
+// WithoutEmbedded excludes the built-in embedded source, so the Registry
+// only ever answers from whatever other sources (WithFS/WithHTTPSource/
+// WithOverrides) are supplied. Useful for hermetic tests and for callers
+// that want to fully replace, rather than patch, the compiled-in data.
+func WithoutEmbedded() Option {
+	return func(r *Registry) { r.skipEmbedded = true }
+}
+
+// NewRegistry builds a Registry from the built-in embedded source (unless
+// WithoutEmbedded is supplied) plus any additional sources supplied via
+// opts, then performs an initial Reload. Sources are merged in the order
+// they end up in Registry.sources - the embedded source, if present, is
+// always first (lowest priority), and each Option appends its source after
+// it, so later options win per identifier. Compose WithFS/WithHTTPSource/
+// WithOverrides in that order for the usual embedded < patch-file < remote
+// < in-memory-override precedence.
+func NewRegistry(opts ...Option) *Registry {
+	r := &Registry{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if !r.skipEmbedded {
+		r.sources = append([]DataSource{embeddedSource{}}, r.sources...)
+	}
+	r.Reload()
+	return r
+}
+
+// Reload re-fetches every source and atomically swaps in the merged result,
+// so concurrent lookups never observe a partially-rebuilt map. It returns a
+// joined error if any source failed, but still applies whatever succeeded.
+func (r *Registry) Reload() error {
+	merged := make(map[string]map[string]DeviceRecord, len(knownPlatforms))
+	var errs []error
+
+	for _, platform := range knownPlatforms {
+		combined := map[string]DeviceRecord{}
+		for _, src := range r.sources {
+			records, err := src.Load(platform)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for code, rec := range records {
+				combined[code] = rec
+			}
+		}
+		merged[platform] = combined
+	}
+
+	r.state.Store(&registryState{
+		records: merged,
+		meta:    readUpstreamMeta("data/UPSTREAM.json"),
+	})
+	return errors.Join(errs...)
+}
+
+func (r *Registry) Lookup(code string) string {
+	info, ok := r.LookupInfo(code)
+	if !ok {
+		return ""
+	}
+	return info.Name
+}
+
+func (r *Registry) LookupDetailed(code string) LookupDetail {
+	info, ok := r.LookupInfo(code)
+	if !ok {
+		return LookupDetail{}
+	}
+	return LookupDetail{Platform: info.Platform, Name: info.Name}
+}
+
+// LookupInfo resolves code by auto-detecting its platform from the
+// identifier's prefix (e.g. "iPhone", "Watch", "AppleTV").
+func (r *Registry) LookupInfo(code string) (DeviceInfo, bool) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return DeviceInfo{}, false
+	}
+
+	st := r.state.Load()
+	if info, ok := lookupInfoByPrefix(st, code); ok {
+		return info, true
+	}
+	for _, platform := range knownPlatforms {
+		if info, ok := lookupRecord(st, platform, platform, code); ok {
+			return info, true
+		}
+	}
+
+	if name := android.LookupAndroidModel(code); name != "" {
+		return DeviceInfo{Identifier: code, Name: name, Platform: PlatformAndroid, Family: "Android"}, true
+	}
+
+	return DeviceInfo{}, false
+}
+
+func (r *Registry) LookupWithPlatform(platform, code string) string {
+	info, ok := r.LookupInfoWithPlatform(platform, code)
+	if !ok {
+		return ""
+	}
+	return info.Name
+}
+
+func (r *Registry) LookupDetailWithPlatform(platform, code string) LookupDetail {
+	info, ok := r.LookupInfoWithPlatform(platform, code)
+	if !ok {
+		return LookupDetail{}
+	}
+	return LookupDetail{Platform: info.Platform, Name: info.Name}
+}
+
+// LookupInfoWithPlatform resolves code against the data for platform.
+// Simulator and Mac Catalyst platforms share their identifier space with a
+// host OS map (real and simulated devices report the same identifier), but
+// the returned DeviceInfo.Platform preserves the platform that was asked
+// for.
+func (r *Registry) LookupInfoWithPlatform(platform, code string) (DeviceInfo, bool) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return DeviceInfo{}, false
+	}
+
+	normalized := NormalizedPlatform(platform)
+	if normalized == "" {
+		return r.LookupInfo(code)
+	}
+
+	canonical := canonicalDataPlatform(normalized)
+	if canonical == "" {
+		return DeviceInfo{}, false
+	}
+
+	return lookupRecord(r.state.Load(), normalized, canonical, code)
+}
+
+// DataVersion reports the upstream provenance of the registry's embedded
+// data.
+func (r *Registry) DataVersion() (upstreamRepo, upstreamRef, syncedAtUTC string) {
+	meta := r.state.Load().meta
+	return meta.UpstreamRepo, meta.UpstreamRef + " sha:" + meta.UpstreamSHA, meta.SyncedAtUTC
+}
+
+func lookupInfoByPrefix(st *registryState, code string) (DeviceInfo, bool) {
+	label, canonical, ok := platformForPrefix(code)
+	if !ok {
+		return DeviceInfo{}, false
+	}
+	return lookupRecord(st, label, canonical, code)
+}
+
+// platformForPrefix identifies the platform an identifier belongs to from
+// its leading characters, returning both the label a result should carry
+// (which may be platform-specific, e.g. PlatformIPADOS) and the canonical
+// data-source key its records live under (e.g. PlatformIOS, since iOS and
+// iPadOS share an identifier space).
+func platformForPrefix(code string) (label, canonical string, ok bool) {
+	switch {
+	case strings.HasPrefix(code, "iPhone"), strings.HasPrefix(code, "iPod"):
+		return PlatformIOS, PlatformIOS, true
+	case strings.HasPrefix(code, "iPad"):
+		return PlatformIPADOS, PlatformIOS, true
+	case strings.HasPrefix(code, "Watch"):
+		return PlatformWatchOS, PlatformWatchOS, true
+	case strings.HasPrefix(code, "AppleTV"):
+		return PlatformTVOS, PlatformTVOS, true
+	case strings.HasPrefix(code, "RealityDevice"):
+		return PlatformVisionOS, PlatformVisionOS, true
+	case strings.HasPrefix(code, "iBridge"):
+		return PlatformBridgeOS, PlatformBridgeOS, true
+	case strings.HasPrefix(code, "iMac"), strings.HasPrefix(code, "Mac"):
+		return PlatformMACOS, PlatformMACOS, true
+	default:
+		return "", "", false
+	}
+}
+
+// lookupRecord looks up code in st.records[canonical] and, on a hit, labels
+// the result with label - which may differ from canonical (e.g. an iPad
+// identifier is labelled PlatformIPADOS despite sharing PlatformIOS's map).
+func lookupRecord(st *registryState, label, canonical, code string) (DeviceInfo, bool) {
+	rec, ok := st.records[canonical][code]
+	if !ok || rec.Name == "" {
+		return DeviceInfo{}, false
+	}
+
+	family := rec.Family
+	if family == "" {
+		family = familyFromCode(code)
+	}
+
+	return DeviceInfo{
+		Identifier:       code,
+		Name:             rec.Name,
+		Platform:         label,
+		Family:           family,
+		FormFactor:       rec.FormFactor,
+		Generation:       rec.Generation,
+		ReleaseYear:      rec.ReleaseYear,
+		Chip:             rec.Chip,
+		Discontinued:     rec.Discontinued,
+		SupportedOSRange: rec.SupportedOSRange,
+	}, true
+}
+
+var (
+	defaultRegistryOnce sync.Once
+	defaultRegistryVal  *Registry
+)
+
+func defaultRegistry() *Registry {
+	defaultRegistryOnce.Do(func() {
+		defaultRegistryVal = NewRegistry()
+	})
+	return defaultRegistryVal
+}
@@ -0,0 +1,75 @@
+package devicemodel
+
+import (
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRegistryOverrideWinsOverEmbedded(t *testing.T) {
+	reg := NewRegistry(WithOverrides(map[string]map[string]DeviceRecord{
+		PlatformIOS: {"iPhone18,1": {Name: "iPhone Overridden"}},
+	}))
+
+	info, ok := reg.LookupInfo("iPhone18,1")
+	if !ok {
+		t.Fatalf("覆盖的标识符应能命中")
+	}
+	if info.Name != "iPhone Overridden" {
+		t.Fatalf("覆盖数据源应优先生效，got=%q", info.Name)
+	}
+	if info.Platform != PlatformIOS {
+		t.Fatalf("platform 异常: got=%q", info.Platform)
+	}
+}
+
+func TestRegistryReloadObservesMutatedOverrides(t *testing.T) {
+	overrides := map[string]map[string]DeviceRecord{
+		PlatformIOS: {"iPhone99,2": {Name: "iPhone Before Reload"}},
+	}
+	reg := NewRegistry(WithOverrides(overrides))
+
+	if name := reg.Lookup("iPhone99,2"); name != "iPhone Before Reload" {
+		t.Fatalf("Reload 前应读到初始值，got=%q", name)
+	}
+
+	overrides[PlatformIOS]["iPhone99,2"] = DeviceRecord{Name: "iPhone After Reload"}
+	if err := reg.Reload(); err != nil {
+		t.Fatalf("Reload 不应返回 error: %v", err)
+	}
+
+	if name := reg.Lookup("iPhone99,2"); name != "iPhone After Reload" {
+		t.Fatalf("Reload 后应读到更新值，got=%q", name)
+	}
+}
+
+func TestRegistryFSSourceOverridesEmbedded(t *testing.T) {
+	fsys := fstest.MapFS{
+		"ios-device-identifiers.json": &fstest.MapFile{
+			Data: []byte(`{"iPhone18,1":{"name":"iPhone From FS"}}`),
+		},
+	}
+	reg := NewRegistry(WithFS(fsys))
+
+	if name := reg.Lookup("iPhone18,1"); name != "iPhone From FS" {
+		t.Fatalf("fs.FS 数据源应生效，got=%q", name)
+	}
+}
+
+func TestRegistryConcurrentLookupDuringReload(t *testing.T) {
+	reg := NewRegistry()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = reg.LookupInfo("iPhone18,1")
+		}()
+		go func() {
+			defer wg.Done()
+			_ = reg.Reload()
+		}()
+	}
+	wg.Wait()
+}
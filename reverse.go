@@ -0,0 +1,87 @@
+package devicemodel
+
+import (
+	"sort"
+	"strings"
+)
+
+// fuzzyMatchDistance is the maximum Levenshtein distance ReverseLookup will
+// accept once no case-insensitive exact match exists.
+const fuzzyMatchDistance = 2
+
+// ReverseLookup returns every identifier whose marketing name matches name,
+// case-insensitively. If at least one exact match exists, only exact matches
+// are returned; otherwise identifiers within fuzzyMatchDistance edits of name
+// are returned instead. Results are sorted for stable output. This is
+// primarily useful for systems (e.g. a CRM) that store the human-readable
+// name and need to reconstruct the identifier it came from.
+func (r *Registry) ReverseLookup(name string) []string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	target := strings.ToLower(name)
+
+	st := r.state.Load()
+	var exact, fuzzy []string
+	for _, records := range st.records {
+		for code, rec := range records {
+			if rec.Name == "" {
+				continue
+			}
+			candidate := strings.ToLower(rec.Name)
+			switch {
+			case candidate == target:
+				exact = append(exact, code)
+			case levenshtein(candidate, target) <= fuzzyMatchDistance:
+				fuzzy = append(fuzzy, code)
+			}
+		}
+	}
+
+	if len(exact) > 0 {
+		sort.Strings(exact)
+		return exact
+	}
+	sort.Strings(fuzzy)
+	return fuzzy
+}
+
+// levenshtein computes the classic edit distance between a and b using a
+// two-row dynamic-programming table.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, minInt(curr[j-1]+1, prev[j-1]+cost))
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,35 @@
+package devicemodel
+
+import "testing"
+
+func newReverseLookupRegistry() *Registry {
+	return NewRegistry(WithoutEmbedded(), WithOverrides(map[string]map[string]DeviceRecord{
+		PlatformIOS: {
+			"iPhone20,1": {Name: "iPhone 17 Pro"},
+			"iPhone20,2": {Name: "iPhone 17 Pro Max"},
+		},
+	}))
+}
+
+func TestReverseLookupExactMatch(t *testing.T) {
+	reg := newReverseLookupRegistry()
+	codes := reg.ReverseLookup("iphone 17 pro")
+	if len(codes) != 1 || codes[0] != "iPhone20,1" {
+		t.Fatalf("精确匹配应只返回 iPhone20,1，got=%v", codes)
+	}
+}
+
+func TestReverseLookupFuzzyMatch(t *testing.T) {
+	reg := newReverseLookupRegistry()
+	codes := reg.ReverseLookup("iPhone 17 Pr") // 缺失末尾 "o"，编辑距离为 1
+	if len(codes) != 1 || codes[0] != "iPhone20,1" {
+		t.Fatalf("模糊匹配应命中 iPhone20,1，got=%v", codes)
+	}
+}
+
+func TestReverseLookupNoMatch(t *testing.T) {
+	reg := newReverseLookupRegistry()
+	if codes := reg.ReverseLookup("Samsung Galaxy S24"); len(codes) != 0 {
+		t.Fatalf("无匹配应返回空，got=%v", codes)
+	}
+}